@@ -0,0 +1,64 @@
+package tracergo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/uber/jaeger-client-go"
+)
+
+// TestAsyncBatchReporterReportDoesNotBlockOnFullBuffer reproduces the
+// scenario from review: Report is called synchronously from whatever
+// goroutine calls span.Finish(), so it must never block even if the
+// background goroutine can't keep up with (or isn't running to drain) the
+// buffer.
+func TestAsyncBatchReporterReportDoesNotBlockOnFullBuffer(t *testing.T) {
+	r := &asyncBatchReporter{spans: make(chan *jaeger.Span, 1)}
+
+	r.Report(&jaeger.Span{}) // fills the buffer; no background loop is draining it
+
+	done := make(chan struct{})
+	go func() {
+		r.Report(&jaeger.Span{}) // must be dropped, not block
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Report blocked on a full buffer instead of dropping the span")
+	}
+}
+
+func TestAsyncBatchReporterFlushesOnIntervalAndClose(t *testing.T) {
+	received := make(chan []*jaeger.Span, 10)
+
+	r := newAsyncBatchReporter(10, 20*time.Millisecond, func(spans []*jaeger.Span) {
+		batch := make([]*jaeger.Span, len(spans))
+		copy(batch, spans)
+		received <- batch
+	})
+
+	r.Report(&jaeger.Span{})
+
+	select {
+	case batch := <-received:
+		if len(batch) != 1 {
+			t.Fatalf("periodic flush batch = %d spans, want 1", len(batch))
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the periodic flush")
+	}
+
+	r.Report(&jaeger.Span{})
+	r.Close()
+
+	select {
+	case batch := <-received:
+		if len(batch) != 1 {
+			t.Fatalf("Close flush batch = %d spans, want 1", len(batch))
+		}
+	default:
+		t.Fatal("Close did not flush the remaining span")
+	}
+}
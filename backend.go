@@ -0,0 +1,286 @@
+package tracergo
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/sirupsen/logrus"
+	"github.com/uber/jaeger-client-go"
+	"github.com/uber/jaeger-client-go/transport"
+)
+
+const (
+	// asyncReporterBufferSize bounds how many spans an asyncBatchReporter
+	// queues before Report starts dropping them, so a stalled/slow collector
+	// backs up memory instead of blocking span.Finish() indefinitely.
+	asyncReporterBufferSize = 1000
+	// asyncReporterFlushInterval matches JaegerBackend's
+	// BufferFlushInterval, so all backends flush on a similar cadence.
+	asyncReporterFlushInterval = 1 * time.Second
+)
+
+// asyncBatchReporter decouples Report (called synchronously from whatever
+// goroutine calls span.Finish(), see jaeger.Tracer.reportSpan) from the
+// actual export, so a slow or unreachable collector stalls neither request
+// handling nor tracing itself. Spans are buffered on a bounded channel
+// drained by a single background goroutine, which hands accumulated batches
+// to send every flushInterval and once more on Close(). A full buffer drops
+// the span (and logs it) rather than blocking the caller.
+type asyncBatchReporter struct {
+	send          func(spans []*jaeger.Span)
+	flushInterval time.Duration
+	spans         chan *jaeger.Span
+	closeCh       chan struct{}
+	closedCh      chan struct{}
+}
+
+func newAsyncBatchReporter(bufferSize int, flushInterval time.Duration, send func(spans []*jaeger.Span)) *asyncBatchReporter {
+	r := &asyncBatchReporter{
+		send:          send,
+		flushInterval: flushInterval,
+		spans:         make(chan *jaeger.Span, bufferSize),
+		closeCh:       make(chan struct{}),
+		closedCh:      make(chan struct{}),
+	}
+
+	go r.loop()
+
+	return r
+}
+
+// Report is the jaeger.Reporter method: it must never block on the
+// collector, so it only ever enqueues.
+func (r *asyncBatchReporter) Report(span *jaeger.Span) {
+	select {
+	case r.spans <- span:
+	default:
+		logrus.Warn("tracer backend: span buffer full, dropping span")
+	}
+}
+
+// Close stops the background goroutine after it flushes whatever is still
+// queued.
+func (r *asyncBatchReporter) Close() {
+	close(r.closeCh)
+	<-r.closedCh
+}
+
+func (r *asyncBatchReporter) loop() {
+	defer close(r.closedCh)
+
+	ticker := time.NewTicker(r.flushInterval)
+	defer ticker.Stop()
+
+	var batch []*jaeger.Span
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+
+		r.send(batch)
+		batch = nil
+	}
+
+	for {
+		select {
+		case span := <-r.spans:
+			batch = append(batch, span)
+		case <-ticker.C:
+			flush()
+		case <-r.closeCh:
+			for {
+				select {
+				case span := <-r.spans:
+					batch = append(batch, span)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// Backend builds the jaeger.Reporter used to ship spans to a particular
+// tracing system. Implementations own their own transport, endpoint and
+// TLS/header configuration so that Config can wire one or several of them
+// together without InitGlobalTracer having to know the details.
+type Backend interface {
+	// Reporter returns the jaeger.Reporter that forwards spans produced by
+	// serviceName to this backend.
+	Reporter(serviceName string) (jaeger.Reporter, error)
+}
+
+// JaegerBackend reports spans to a Jaeger agent (UDP) or collector (HTTP),
+// exactly as InitGlobalTracer did before backends were pluggable.
+type JaegerBackend struct {
+	AgentHost         string
+	CollectorEndpoint string
+}
+
+func (b JaegerBackend) Reporter(string) (jaeger.Reporter, error) {
+	if b.AgentHost == "" && b.CollectorEndpoint == "" {
+		logrus.Info("Jaeger client configured to be silent")
+		return jaeger.NewNullReporter(), nil
+	}
+
+	var sender jaeger.Transport
+
+	if b.CollectorEndpoint != "" {
+		sender = transport.NewHTTPTransport(b.CollectorEndpoint)
+		logrus.Infof("Jaeger client configured to use the collector: %s", b.CollectorEndpoint)
+	} else {
+		var err error
+
+		sender, err = jaeger.NewUDPTransport(b.AgentHost, 0)
+		if err != nil {
+			return nil, fmt.Errorf("jaeger transport initialization error: %w", err)
+		}
+
+		logrus.Infof("Jaeger client configured to use the agent: %s", b.AgentHost)
+	}
+
+	return jaeger.NewRemoteReporter(
+		sender,
+		jaeger.ReporterOptions.BufferFlushInterval(1*time.Second),
+		jaeger.ReporterOptions.Logger(jaeger.StdLogger),
+	), nil
+}
+
+// ZipkinBackend reports spans to a Zipkin collector's HTTP API (v2 JSON),
+// for teams running a Zipkin backend instead of Jaeger.
+type ZipkinBackend struct {
+	CollectorURL string
+	Headers      map[string]string
+	TLSConfig    *tls.Config
+	Timeout      time.Duration
+}
+
+func (b ZipkinBackend) Reporter(serviceName string) (jaeger.Reporter, error) {
+	if b.CollectorURL == "" {
+		return nil, fmt.Errorf("zipkin backend: CollectorURL is required")
+	}
+
+	timeout := b.Timeout
+	if timeout == 0 {
+		timeout = 5 * time.Second
+	}
+
+	client := &http.Client{
+		Timeout: timeout,
+	}
+	if b.TLSConfig != nil {
+		client.Transport = &http.Transport{TLSClientConfig: b.TLSConfig}
+	}
+
+	r := &zipkinReporter{
+		serviceName:  serviceName,
+		collectorURL: b.CollectorURL,
+		headers:      b.Headers,
+		client:       client,
+	}
+	r.async = newAsyncBatchReporter(asyncReporterBufferSize, asyncReporterFlushInterval, r.export)
+
+	return r, nil
+}
+
+// zipkinSpan is the subset of the Zipkin v2 span JSON model we fill in from
+// a reported jaeger.Span.
+type zipkinSpan struct {
+	TraceID       string            `json:"traceId"`
+	ID            string            `json:"id"`
+	ParentID      string            `json:"parentId,omitempty"`
+	Name          string            `json:"name"`
+	Timestamp     int64             `json:"timestamp"`
+	Duration      int64             `json:"duration"`
+	LocalEndpoint map[string]string `json:"localEndpoint"`
+	Tags          map[string]string `json:"tags,omitempty"`
+}
+
+type zipkinReporter struct {
+	serviceName  string
+	collectorURL string
+	headers      map[string]string
+	client       *http.Client
+	async        *asyncBatchReporter
+}
+
+// Report hands the span to the asyncBatchReporter so the actual HTTP export
+// happens off the caller's goroutine.
+func (r *zipkinReporter) Report(span *jaeger.Span) {
+	r.async.Report(span)
+}
+
+func (r *zipkinReporter) Close() {
+	r.async.Close()
+}
+
+// export POSTs a batch of spans to the Zipkin collector. It runs on the
+// asyncBatchReporter's background goroutine, never on the goroutine that
+// called span.Finish().
+func (r *zipkinReporter) export(spans []*jaeger.Span) {
+	zipkinSpans := make([]zipkinSpan, 0, len(spans))
+
+	for _, span := range spans {
+		ctx := span.SpanContext()
+
+		zs := zipkinSpan{
+			TraceID:       ctx.TraceID().String(),
+			ID:            ctx.SpanID().String(),
+			Name:          span.OperationName(),
+			Timestamp:     span.StartTime().UnixNano() / int64(time.Microsecond),
+			Duration:      span.Duration().Nanoseconds() / int64(time.Microsecond),
+			LocalEndpoint: map[string]string{"serviceName": r.serviceName},
+			Tags:          tagsToStrings(span.Tags()),
+		}
+		if ctx.ParentID() != 0 {
+			zs.ParentID = ctx.ParentID().String()
+		}
+
+		zipkinSpans = append(zipkinSpans, zs)
+	}
+
+	body, err := json.Marshal(zipkinSpans)
+	if err != nil {
+		logrus.Warnf("zipkin backend: failed to marshal spans: %s", err.Error())
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodPost, r.collectorURL, bytes.NewReader(body))
+	if err != nil {
+		logrus.Warnf("zipkin backend: failed to build request: %s", err.Error())
+		return
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range r.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		logrus.Warnf("zipkin backend: failed to export spans: %s", err.Error())
+		return
+	}
+
+	_ = resp.Body.Close()
+}
+
+func tagsToStrings(tags opentracing.Tags) map[string]string {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	out := make(map[string]string, len(tags))
+	for k, v := range tags {
+		out[k] = fmt.Sprintf("%v", v)
+	}
+
+	return out
+}
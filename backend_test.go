@@ -0,0 +1,32 @@
+package tracergo
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+)
+
+func TestTagsToStrings(t *testing.T) {
+	tests := []struct {
+		name string
+		tags opentracing.Tags
+		want map[string]string
+	}{
+		{name: "empty", tags: nil, want: nil},
+		{
+			name: "mixed types",
+			tags: opentracing.Tags{"http.status_code": 200, "error": true, "db.type": "mysql"},
+			want: map[string]string{"http.status_code": "200", "error": "true", "db.type": "mysql"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tagsToStrings(tt.tags)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tagsToStrings(%v) = %v, want %v", tt.tags, got, tt.want)
+			}
+		})
+	}
+}
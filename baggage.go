@@ -0,0 +1,178 @@
+package tracergo
+
+import (
+	"fmt"
+	"regexp"
+	"sync/atomic"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	baggageKeyTenantID = "tenant.id"
+	baggageKeyUserID   = "user.id"
+)
+
+// wellKnownBaggageKeys are copied as span tags by tagWellKnownBaggage so
+// that SetTenantID/SetUserID show up as filterable fields in the Jaeger UI
+// on every descendant span, not just the one baggage was set on.
+var wellKnownBaggageKeys = [...]string{baggageKeyTenantID, baggageKeyUserID}
+
+// BaggagePolicy bounds what AddBaggage accepts, since baggage propagates to
+// every descendant span and often across service boundaries: unconstrained
+// baggage has been a source of header bloat and PII leakage.
+type BaggagePolicy struct {
+	// AllowedKeys restricts which keys AddBaggage accepts. A nil or empty
+	// slice allows any key.
+	AllowedKeys []string
+	// MaxValueBytes caps the length of a single baggage value. Zero means
+	// no per-value limit.
+	MaxValueBytes int
+	// MaxTotalBytes caps the combined length of all baggage key/value
+	// pairs already on the span plus the one being added. Zero means no
+	// total limit.
+	MaxTotalBytes int
+	// Validators optionally constrains a key's value to match a regexp.
+	Validators map[string]*regexp.Regexp
+}
+
+// BaggageRestrictionsConfig fetches the set of allowed baggage keys from the
+// Jaeger agent at HostPort instead of (or alongside) the static BaggagePolicy
+// enforced locally by AddBaggage. Setting it on Config routes Init through
+// jaeger-client-go's own config package, the only supported way to reach its
+// remote restriction manager from outside that module.
+type BaggageRestrictionsConfig struct {
+	// HostPort is the jaeger-agent address (host:port) to poll for baggage
+	// restrictions, e.g. "localhost:5778".
+	HostPort string
+	// RefreshInterval controls how often restrictions are re-fetched. Zero
+	// uses jaeger-client-go's default.
+	RefreshInterval time.Duration
+	// DenyBaggageOnInitializationFailure makes the tracer drop every baggage
+	// item set before the first successful fetch from HostPort; AddBaggage
+	// still returns nil for items dropped this way. The default allows
+	// baggage during that window.
+	DenyBaggageOnInitializationFailure bool
+}
+
+var currentBaggagePolicy atomic.Value // BaggagePolicy
+
+// SetBaggagePolicy installs the policy enforced by AddBaggage. It is meant
+// to be called once during startup, alongside Init.
+func SetBaggagePolicy(policy BaggagePolicy) {
+	currentBaggagePolicy.Store(policy)
+}
+
+func baggagePolicy() BaggagePolicy {
+	policy, _ := currentBaggagePolicy.Load().(BaggagePolicy)
+	return policy
+}
+
+// AddBaggage to add baggage in span if span is valid
+// sets a key:value pair on this Span and its SpanContext
+// that also propagates to descendants of this Span.
+//
+// The key/value pair is validated against the BaggagePolicy installed with
+// SetBaggagePolicy, if any. A rejected key/value is logged at debug level
+// and returned as an error instead of being silently dropped.
+func AddBaggage(span opentracing.Span, key string, value string) error {
+	if span == nil {
+		return nil
+	}
+
+	if err := baggagePolicy().validate(span, key, value); err != nil {
+		logrus.Debugf("tracergo: rejected baggage %q: %s", key, err.Error())
+		return err
+	}
+
+	span.SetBaggageItem(key, value)
+
+	return nil
+}
+
+func (p BaggagePolicy) validate(span opentracing.Span, key, value string) error {
+	if len(p.AllowedKeys) > 0 {
+		allowed := false
+
+		for _, k := range p.AllowedKeys {
+			if k == key {
+				allowed = true
+				break
+			}
+		}
+
+		if !allowed {
+			return fmt.Errorf("baggage key %q is not in the allow-list", key)
+		}
+	}
+
+	if p.MaxValueBytes > 0 && len(value) > p.MaxValueBytes {
+		return fmt.Errorf("baggage value for %q exceeds %d bytes", key, p.MaxValueBytes)
+	}
+
+	if validator, ok := p.Validators[key]; ok && !validator.MatchString(value) {
+		return fmt.Errorf("baggage value for %q does not match the required pattern", key)
+	}
+
+	if p.MaxTotalBytes > 0 {
+		total := len(key) + len(value)
+
+		span.Context().ForeachBaggageItem(func(k, v string) bool {
+			total += len(k) + len(v)
+			return true
+		})
+
+		if total > p.MaxTotalBytes {
+			return fmt.Errorf("baggage would exceed the %d byte total cap", p.MaxTotalBytes)
+		}
+	}
+
+	return nil
+}
+
+// SetTenantID stores the tenant ID as baggage under a well-known key so it
+// propagates to every descendant span and is tagged on them automatically.
+func SetTenantID(span opentracing.Span, tenantID string) error {
+	return AddBaggage(span, baggageKeyTenantID, tenantID)
+}
+
+// GetTenantID reads back the tenant ID set by SetTenantID, if any.
+func GetTenantID(span opentracing.Span) string {
+	return baggageItem(span, baggageKeyTenantID)
+}
+
+// SetUserID stores the user ID as baggage under a well-known key so it
+// propagates to every descendant span and is tagged on them automatically.
+func SetUserID(span opentracing.Span, userID string) error {
+	return AddBaggage(span, baggageKeyUserID, userID)
+}
+
+// GetUserID reads back the user ID set by SetUserID, if any.
+func GetUserID(span opentracing.Span) string {
+	return baggageItem(span, baggageKeyUserID)
+}
+
+func baggageItem(span opentracing.Span, key string) string {
+	if span == nil {
+		return ""
+	}
+
+	return span.BaggageItem(key)
+}
+
+// tagWellKnownBaggage copies SetTenantID/SetUserID baggage onto span as
+// tags, so they show up as filterable fields in the Jaeger UI on every
+// descendant span instead of only the one they were set on.
+func tagWellKnownBaggage(span opentracing.Span) {
+	if span == nil {
+		return
+	}
+
+	for _, key := range wellKnownBaggageKeys {
+		if value := span.BaggageItem(key); value != "" {
+			AddTag(span, key, value)
+		}
+	}
+}
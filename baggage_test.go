@@ -0,0 +1,94 @@
+package tracergo
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+func TestBaggagePolicyValidate(t *testing.T) {
+	tracer := mocktracer.New()
+	span := tracer.StartSpan("op")
+
+	tests := []struct {
+		name    string
+		policy  BaggagePolicy
+		key     string
+		value   string
+		wantErr bool
+	}{
+		{name: "no policy allows anything", policy: BaggagePolicy{}, key: "tenant.id", value: "acme"},
+		{
+			name:    "key not in allow-list",
+			policy:  BaggagePolicy{AllowedKeys: []string{"tenant.id"}},
+			key:     "user.id",
+			value:   "1",
+			wantErr: true,
+		},
+		{
+			name:   "key in allow-list",
+			policy: BaggagePolicy{AllowedKeys: []string{"tenant.id"}},
+			key:    "tenant.id",
+			value:  "acme",
+		},
+		{
+			name:    "value exceeds MaxValueBytes",
+			policy:  BaggagePolicy{MaxValueBytes: 3},
+			key:     "tenant.id",
+			value:   "acme",
+			wantErr: true,
+		},
+		{
+			name:    "value fails validator pattern",
+			policy:  BaggagePolicy{Validators: map[string]*regexp.Regexp{"tenant.id": regexp.MustCompile(`^[0-9]+$`)}},
+			key:     "tenant.id",
+			value:   "acme",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.validate(span, tt.key, tt.value)
+			if tt.wantErr && err == nil {
+				t.Fatalf("validate(%q, %q) returned no error, want one", tt.key, tt.value)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("validate(%q, %q) returned unexpected error: %s", tt.key, tt.value, err)
+			}
+		})
+	}
+}
+
+func TestSetTenantIDAndGetTenantID(t *testing.T) {
+	SetBaggagePolicy(BaggagePolicy{})
+	defer SetBaggagePolicy(BaggagePolicy{})
+
+	tracer := mocktracer.New()
+	span := tracer.StartSpan("op")
+
+	if err := SetTenantID(span, "acme"); err != nil {
+		t.Fatalf("SetTenantID returned unexpected error: %s", err)
+	}
+
+	if got := GetTenantID(span); got != "acme" {
+		t.Errorf("GetTenantID() = %q, want %q", got, "acme")
+	}
+
+	if got := GetUserID(span); got != "" {
+		t.Errorf("GetUserID() = %q, want empty", got)
+	}
+}
+
+func TestAddBaggageRejectsDisallowedKey(t *testing.T) {
+	SetBaggagePolicy(BaggagePolicy{AllowedKeys: []string{"tenant.id"}})
+	defer SetBaggagePolicy(BaggagePolicy{})
+
+	tracer := mocktracer.New()
+	span := tracer.StartSpan("op")
+
+	if err := AddBaggage(span, "user.id", "1"); err == nil {
+		t.Fatal("AddBaggage returned no error for a disallowed key, want one")
+	}
+}
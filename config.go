@@ -0,0 +1,149 @@
+package tracergo
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/uber/jaeger-client-go"
+	jaegercfg "github.com/uber/jaeger-client-go/config"
+	"github.com/uber/jaeger-client-go/zipkin"
+)
+
+// Config describes how to build the global tracer: which service it
+// belongs to and which Backend(s) it ships spans to. Passing more than one
+// Backend fans every span out to all of them.
+type Config struct {
+	ServiceName string
+	Realm       string
+	Backends    []Backend
+	// Sampler configures which spans get sampled. The zero value samples
+	// every span, matching the hard-coded behavior InitGlobalTracer used to
+	// have.
+	Sampler SamplerConfig
+	// BaggageRestrictions, if set, fetches the allowed baggage keys remotely
+	// from a Jaeger agent instead of relying solely on the static
+	// BaggagePolicy installed with SetBaggagePolicy.
+	BaggageRestrictions *BaggageRestrictionsConfig
+}
+
+// Init builds an opentracing.Tracer for cfg and installs it as the global
+// tracer. The returned io.Closer must be closed on shutdown to flush any
+// buffered spans.
+func Init(cfg Config) (opentracing.Tracer, io.Closer, error) {
+	if len(cfg.Backends) == 0 {
+		return nil, nil, fmt.Errorf("tracergo: Config.Backends must not be empty")
+	}
+
+	fullServiceName := cfg.ServiceName
+	if cfg.Realm != "" {
+		fullServiceName = cfg.ServiceName + "." + cfg.Realm
+	}
+
+	reporters := make([]jaeger.Reporter, 0, len(cfg.Backends))
+
+	for _, backend := range cfg.Backends {
+		reporter, err := backend.Reporter(fullServiceName)
+		if err != nil {
+			return nil, nil, fmt.Errorf("tracergo: %w", err)
+		}
+
+		reporters = append(reporters, reporter)
+	}
+
+	reporter := reporters[0]
+	if len(reporters) > 1 {
+		reporter = jaeger.NewCompositeReporter(reporters...)
+	}
+
+	sampler, err := buildSampler(fullServiceName, cfg.Sampler)
+	if err != nil {
+		return nil, nil, fmt.Errorf("tracergo: %w", err)
+	}
+
+	zipkinPropagator := zipkin.NewZipkinB3HTTPHeaderPropagator()
+
+	var newTracer opentracing.Tracer
+	var closer io.Closer
+
+	if cfg.BaggageRestrictions != nil {
+		newTracer, closer, err = newTracerWithRemoteBaggageRestrictions(fullServiceName, cfg.BaggageRestrictions, sampler, reporter, zipkinPropagator)
+	} else {
+		newTracer, closer, err = newTracerDirect(fullServiceName, sampler, reporter, zipkinPropagator)
+	}
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("tracergo: %w", err)
+	}
+
+	opentracing.SetGlobalTracer(newTracer)
+
+	return newTracer, closer, nil
+}
+
+// newTracerDirect builds the tracer the way Init always has, via
+// jaeger.NewTracer, for the common case where no BaggageRestrictions are
+// configured.
+func newTracerDirect(
+	serviceName string,
+	sampler jaeger.Sampler,
+	reporter jaeger.Reporter,
+	zipkinPropagator zipkin.Propagator,
+) (opentracing.Tracer, io.Closer, error) {
+	injector := jaeger.TracerOptions.Injector(opentracing.HTTPHeaders, zipkinPropagator)
+	extractor := jaeger.TracerOptions.Extractor(opentracing.HTTPHeaders, zipkinPropagator)
+
+	// Zipkin shares span ID between client and server spans; it must be enabled via the following option.
+	zipkinSharedRPCSpan := jaeger.TracerOptions.ZipkinSharedRPCSpan(true)
+
+	newTracer, closer := jaeger.NewTracer(
+		serviceName,
+		sampler,
+		reporter,
+		injector,
+		extractor,
+		zipkinSharedRPCSpan,
+		jaeger.TracerOptions.PoolSpans(false),
+	)
+
+	return newTracer, closer, nil
+}
+
+// newTracerWithRemoteBaggageRestrictions builds the tracer through
+// jaeger-client-go's config package instead of calling jaeger.NewTracer
+// directly, since the remote restriction manager it builds
+// (jaeger.TracerOptions.BaggageRestrictionManager's parameter type) lives in
+// an internal package this module cannot import. config.Configuration.NewTracer
+// constructs it for us; config.Reporter/config.Sampler/config.Injector/
+// config.Extractor let us keep the reporter and sampler already built above
+// and the same Zipkin B3 propagation newTracerDirect uses.
+func newTracerWithRemoteBaggageRestrictions(
+	serviceName string,
+	restrictions *BaggageRestrictionsConfig,
+	sampler jaeger.Sampler,
+	reporter jaeger.Reporter,
+	zipkinPropagator zipkin.Propagator,
+) (opentracing.Tracer, io.Closer, error) {
+	jcfg := jaegercfg.Configuration{
+		ServiceName: serviceName,
+		BaggageRestrictions: &jaegercfg.BaggageRestrictionsConfig{
+			DenyBaggageOnInitializationFailure: restrictions.DenyBaggageOnInitializationFailure,
+			HostPort:                           restrictions.HostPort,
+			RefreshInterval:                    restrictions.RefreshInterval,
+		},
+	}
+
+	newTracer, closer, err := jcfg.NewTracer(
+		jaegercfg.Reporter(reporter),
+		jaegercfg.Sampler(sampler),
+		jaegercfg.Injector(opentracing.HTTPHeaders, zipkinPropagator),
+		jaegercfg.Extractor(opentracing.HTTPHeaders, zipkinPropagator),
+		jaegercfg.ZipkinSharedRPCSpan(true),
+		jaegercfg.PoolSpans(false),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("jaeger config: %w", err)
+	}
+
+	return newTracer, closer, nil
+}
@@ -0,0 +1,78 @@
+package tracergo
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/uber/jaeger-client-go"
+)
+
+// fakeReporter is a jaeger.Reporter that records every span it is given, so
+// tests can assert on fan-out without standing up a real collector.
+type fakeReporter struct {
+	mu    sync.Mutex
+	spans []*jaeger.Span
+}
+
+func (r *fakeReporter) Report(span *jaeger.Span) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.spans = append(r.spans, span)
+}
+
+func (r *fakeReporter) Close() {}
+
+func (r *fakeReporter) reported() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.spans)
+}
+
+// fakeBackend is a Backend backed by a fixed fakeReporter, for exercising
+// Init's fan-out without any real transport.
+type fakeBackend struct {
+	reporter *fakeReporter
+}
+
+func (b fakeBackend) Reporter(string) (jaeger.Reporter, error) {
+	return b.reporter, nil
+}
+
+func TestInitRejectsEmptyBackends(t *testing.T) {
+	tracer, closer, err := Init(Config{ServiceName: "svc"})
+	if tracer != nil || closer != nil {
+		t.Fatalf("Init(Config{}) = (%v, %v), want (nil, nil)", tracer, closer)
+	}
+
+	if err == nil || !strings.Contains(err.Error(), "Backends must not be empty") {
+		t.Fatalf("Init(Config{}) err = %v, want it to mention Backends must not be empty", err)
+	}
+}
+
+func TestInitFansOutToEveryBackend(t *testing.T) {
+	first := &fakeReporter{}
+	second := &fakeReporter{}
+
+	tracer, closer, err := Init(Config{
+		ServiceName: "svc",
+		Backends:    []Backend{fakeBackend{reporter: first}, fakeBackend{reporter: second}},
+	})
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	defer closer.Close()
+
+	span := tracer.StartSpan("op")
+	span.Finish()
+
+	if got := first.reported(); got != 1 {
+		t.Errorf("first backend received %d spans, want 1", got)
+	}
+
+	if got := second.reported(); got != 1 {
+		t.Errorf("second backend received %d spans, want 1", got)
+	}
+}
@@ -0,0 +1,127 @@
+package tracergo
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+
+	pkgerrors "github.com/pkg/errors"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/log"
+)
+
+// stackTracer is implemented by errors created with github.com/pkg/errors,
+// whose StackTrace() already carries the frames captured where the error
+// was created.
+type stackTracer interface {
+	StackTrace() pkgerrors.StackTrace
+}
+
+// TraceError sends a log and a tag with Error into tracer
+//
+// The log follows the OpenTracing semantic conventions used by Jaeger UI's
+// error panel: error.kind is the type of the root cause (walking
+// errors.Unwrap), error.object/error.message describe the error itself, and
+// error.stack is either the stack captured where the error was created
+// (github.com/pkg/errors) or, failing that, the stack at the TraceError
+// call site. Errored spans are also marked sampling.priority=1 so
+// tail-sampling backends keep them even when the head sampler would have
+// dropped the trace.
+func TraceError(span opentracing.Span, err error) {
+	traceError(span, err)
+}
+
+// TraceErrorf is TraceError for a formatted error, so call sites don't need
+// a separate fmt.Errorf just to report a failure on a span. It calls
+// traceError directly rather than through TraceError so that both reach
+// errorStack/captureStack at the same stack depth; if it called TraceError
+// instead, the extra frame would make captureStack's fallback stack start
+// one level too shallow, at TraceErrorf itself.
+func TraceErrorf(span opentracing.Span, format string, args ...interface{}) {
+	traceError(span, fmt.Errorf(format, args...))
+}
+
+func traceError(span opentracing.Span, err error) {
+	if span == nil || err == nil {
+		return
+	}
+
+	AddTag(span, "error", "true")
+	ext.SamplingPriority.Set(span, 1)
+
+	span.LogFields(
+		log.Event("error"),
+		log.String("error.kind", fmt.Sprintf("%T", rootCause(err))),
+		log.String("error.object", fmt.Sprintf("%+v", err)),
+		log.String("error.message", err.Error()),
+		log.String("error.stack", errorStack(err)),
+	)
+}
+
+// TracePanic recovers a panic and records it on span with a full stack
+// trace before re-panicking, so the process still crashes/unwinds as it
+// would without tracing but the trace captures why. Use it as:
+//
+//	defer tracergo.TracePanic(span)
+func TracePanic(span opentracing.Span) {
+	if r := recover(); r != nil {
+		err, ok := r.(error)
+		if !ok {
+			err = fmt.Errorf("panic: %v", r)
+		}
+
+		TraceError(span, err)
+
+		panic(r)
+	}
+}
+
+// rootCause walks err's Unwrap chain to the innermost error, which is
+// usually the most useful value for error.kind.
+func rootCause(err error) error {
+	for {
+		unwrapped := errors.Unwrap(err)
+		if unwrapped == nil {
+			return err
+		}
+
+		err = unwrapped
+	}
+}
+
+// errorStack returns the stack trace carried by err (or anything it wraps)
+// if it was created with github.com/pkg/errors, otherwise it captures the
+// stack at the TraceError call site as a best effort.
+func errorStack(err error) string {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if tracer, ok := e.(stackTracer); ok {
+			return fmt.Sprintf("%+v", tracer.StackTrace())
+		}
+	}
+
+	return captureStack()
+}
+
+func captureStack() string {
+	pc := make([]uintptr, 32)
+	n := runtime.Callers(5, pc) // skip captureStack, errorStack, traceError, TraceError/TraceErrorf, and land on their caller.
+
+	frames := runtime.CallersFrames(pc[:n])
+
+	var sb strings.Builder
+
+	for {
+		frame, more := frames.Next()
+
+		fmt.Fprintf(&sb, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+
+		if !more {
+			break
+		}
+	}
+
+	return sb.String()
+}
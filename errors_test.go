@@ -0,0 +1,84 @@
+package tracergo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+func TestRootCause(t *testing.T) {
+	base := errors.New("disk full")
+	wrapped := fmt.Errorf("write failed: %w", base)
+
+	if got := rootCause(wrapped); got != base {
+		t.Errorf("rootCause(wrapped) = %v, want %v", got, base)
+	}
+
+	if got := rootCause(base); got != base {
+		t.Errorf("rootCause(base) = %v, want %v", got, base)
+	}
+}
+
+func TestErrorStack(t *testing.T) {
+	stacked := pkgerrors.New("boom")
+	if got := errorStack(stacked); !strings.Contains(got, "TestErrorStack") {
+		t.Errorf("errorStack(stacked) = %q, want it to contain the call site", got)
+	}
+
+	plain := errors.New("boom")
+	if got := errorStack(plain); got == "" {
+		t.Error("errorStack(plain) returned an empty fallback stack")
+	}
+}
+
+func errorStackLog(t *testing.T, span *mocktracer.MockSpan) string {
+	t.Helper()
+
+	logs := span.Logs()
+	for _, kv := range logs[len(logs)-1].Fields {
+		if kv.Key == "error.stack" {
+			return kv.ValueString
+		}
+	}
+
+	t.Fatal("no error.stack field logged")
+
+	return ""
+}
+
+// TestTraceErrorAndTraceErrorfCaptureSameCallDepth guards against the
+// fallback stack (used for errors not created with github.com/pkg/errors)
+// starting one frame too shallow on the TraceErrorf path, which would point
+// at TraceErrorf itself instead of its caller.
+func TestTraceErrorAndTraceErrorfCaptureSameCallDepth(t *testing.T) {
+	tracer := mocktracer.New()
+	span := tracer.StartSpan("op").(*mocktracer.MockSpan)
+
+	TraceError(span, errors.New("boom"))
+
+	if got := firstStackFrame(errorStackLog(t, span)); !strings.Contains(got, "TestTraceErrorAndTraceErrorfCaptureSameCallDepth") {
+		t.Errorf("TraceError error.stack starts at %q, want this test function", got)
+	}
+
+	TraceErrorf(span, "boom: %d", 42)
+
+	if got := firstStackFrame(errorStackLog(t, span)); !strings.Contains(got, "TestTraceErrorAndTraceErrorfCaptureSameCallDepth") {
+		t.Errorf("TraceErrorf error.stack starts at %q, want this test function, not TraceErrorf/traceError", got)
+	}
+}
+
+// firstStackFrame returns the function name on the first line of a stack
+// captured by captureStack, e.g. "tracergo.captureStack" from
+// "tracergo.captureStack\n\tfile.go:123\n...".
+func firstStackFrame(stack string) string {
+	if i := strings.Index(stack, "\n"); i != -1 {
+		return stack[:i]
+	}
+
+	return stack
+}
@@ -0,0 +1,97 @@
+package tracergo
+
+import (
+	"context"
+	"strings"
+
+	"github.com/emicklei/go-restful"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+)
+
+// FilterOption configures the behavior of Filter.
+type FilterOption func(*filterConfig)
+
+type filterConfig struct {
+	operationName func(req *restful.Request) string
+	ignoredPaths  map[string]bool
+	addTags       func(span opentracing.Span, req *restful.Request)
+}
+
+// WithOperationName overrides how the span name is derived from the
+// incoming request. Defaults to req.SelectedRoutePath().
+func WithOperationName(f func(req *restful.Request) string) FilterOption {
+	return func(c *filterConfig) {
+		c.operationName = f
+	}
+}
+
+// WithIgnoredPaths skips starting a span for the given route paths, e.g.
+// "/healthz" and "/metrics".
+func WithIgnoredPaths(paths ...string) FilterOption {
+	return func(c *filterConfig) {
+		for _, p := range paths {
+			c.ignoredPaths[p] = true
+		}
+	}
+}
+
+// WithCustomTags registers a hook invoked after the span is started so
+// callers can add route-specific tags.
+func WithCustomTags(f func(span opentracing.Span, req *restful.Request)) FilterOption {
+	return func(c *filterConfig) {
+		c.addTags = f
+	}
+}
+
+// Filter returns a restful.FilterFunction that starts a server span for
+// every incoming request (extracting the parent SpanContext if present),
+// stores it under SpanContextKey for GetSpanFromRestfulContext, and tags
+// http.status_code/error on completion. Register it once on the
+// restful.Container to stop hand-rolling StartSpan/Finish in every route
+// handler.
+func Filter(opts ...FilterOption) restful.FilterFunction {
+	cfg := &filterConfig{
+		ignoredPaths: map[string]bool{},
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return func(req *restful.Request, resp *restful.Response, chain *restful.FilterChain) {
+		path := req.SelectedRoutePath()
+		if cfg.ignoredPaths[path] || cfg.ignoredPaths[strings.TrimSuffix(path, "/")] {
+			chain.ProcessFilter(req, resp)
+			return
+		}
+
+		operationName := path
+		if cfg.operationName != nil {
+			operationName = cfg.operationName(req)
+		}
+
+		span, ctx := StartSpan(req, operationName)
+		defer Finish(span)
+
+		if cfg.addTags != nil {
+			cfg.addTags(span, req)
+		}
+
+		ctx = contextWithForwardedHeaders(ctx, req)
+		req.Request = req.Request.WithContext(
+			contextWithSpanValue(ctx, span),
+		)
+
+		chain.ProcessFilter(req, resp)
+
+		ext.HTTPStatusCode.Set(span, uint16(resp.StatusCode()))
+
+		if resp.StatusCode() >= 500 {
+			AddTag(span, "error", "true")
+		}
+	}
+}
+
+func contextWithSpanValue(ctx context.Context, span opentracing.Span) context.Context {
+	return context.WithValue(ctx, SpanContextKey, span)
+}
@@ -0,0 +1,93 @@
+package tracergo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/emicklei/go-restful"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+func newTestContainer(opts ...FilterOption) *restful.Container {
+	container := restful.NewContainer()
+
+	ws := new(restful.WebService).Path("/api")
+	ws.Filter(Filter(opts...))
+	ws.Route(ws.GET("/ok").To(func(req *restful.Request, resp *restful.Response) {
+		resp.WriteHeader(http.StatusOK)
+	}))
+	ws.Route(ws.GET("/boom").To(func(req *restful.Request, resp *restful.Response) {
+		resp.WriteHeader(http.StatusInternalServerError)
+	}))
+	container.Add(ws)
+
+	return container
+}
+
+func TestFilterTagsStatusCodeAndError(t *testing.T) {
+	tracer := mocktracer.New()
+	prev := opentracing.GlobalTracer()
+	opentracing.SetGlobalTracer(tracer)
+	defer opentracing.SetGlobalTracer(prev)
+
+	container := newTestContainer()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/boom", nil)
+	resp := httptest.NewRecorder()
+	container.ServeHTTP(resp, req)
+
+	spans := tracer.FinishedSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 finished span, got %d", len(spans))
+	}
+
+	span := spans[0]
+	if span.OperationName != "/api/boom" {
+		t.Errorf("OperationName = %q, want %q", span.OperationName, "/api/boom")
+	}
+
+	if got := span.Tag("http.status_code"); got != uint16(http.StatusInternalServerError) {
+		t.Errorf("http.status_code tag = %v, want %v", got, http.StatusInternalServerError)
+	}
+
+	if got := span.Tag("error"); got != "true" {
+		t.Errorf("error tag = %v, want %q", got, "true")
+	}
+}
+
+func TestFilterDoesNotTagErrorOnSuccess(t *testing.T) {
+	tracer := mocktracer.New()
+	prev := opentracing.GlobalTracer()
+	opentracing.SetGlobalTracer(tracer)
+	defer opentracing.SetGlobalTracer(prev)
+
+	container := newTestContainer()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/ok", nil)
+	resp := httptest.NewRecorder()
+	container.ServeHTTP(resp, req)
+
+	span := tracer.FinishedSpans()[0]
+	if got := span.Tag("error"); got != nil {
+		t.Errorf("error tag = %v, want nil", got)
+	}
+}
+
+func TestFilterIgnoredPaths(t *testing.T) {
+	tracer := mocktracer.New()
+	prev := opentracing.GlobalTracer()
+	opentracing.SetGlobalTracer(tracer)
+	defer opentracing.SetGlobalTracer(prev)
+
+	container := newTestContainer(WithIgnoredPaths("/api/ok"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/ok", nil)
+	resp := httptest.NewRecorder()
+	container.ServeHTTP(resp, req)
+
+	if spans := tracer.FinishedSpans(); len(spans) != 0 {
+		t.Fatalf("expected no spans for ignored path, got %d", len(spans))
+	}
+}
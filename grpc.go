@@ -0,0 +1,229 @@
+package tracergo
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// grpcMetadataCarrier adapts metadata.MD to opentracing.TextMapWriter/Reader so
+// SpanContexts can be injected into / extracted from it the same way
+// InjectTrace does for http.Header.
+type grpcMetadataCarrier metadata.MD
+
+func (c grpcMetadataCarrier) Set(key, val string) {
+	metadata.MD(c).Append(key, val)
+}
+
+func (c grpcMetadataCarrier) ForeachKey(handler func(key, val string) error) error {
+	for k, vs := range c {
+		for _, v := range vs {
+			if err := handler(k, v); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// UnaryClientInterceptor starts a child span for each outgoing unary RPC
+// and injects it into the request's gRPC metadata.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		span, ctx := StartSpanFromContext(ctx, "grpc "+method)
+		defer Finish(span)
+
+		ctx = injectGRPCSpan(ctx, span)
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err != nil {
+			TraceError(span, err)
+		}
+
+		return err
+	}
+}
+
+// StreamClientInterceptor starts a child span for each outgoing streaming
+// RPC and injects it into the stream's gRPC metadata. The span is finished
+// when the stream terminates (RecvMsg/SendMsg returning a terminal error
+// such as io.EOF, or, for non-server-streaming RPCs, the single successful
+// RecvMsg that delivers the response), not when this function returns,
+// since the stream is typically still in use after streamer() hands it
+// back. CloseSend only half-closes the send direction and must not finish
+// the span: generated client-streaming stubs call CloseSend() and then
+// RecvMsg() for the response, so finishing on CloseSend would end the span
+// before the RPC's outcome is known.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		span, ctx := StartSpanFromContext(ctx, "grpc "+method)
+
+		ctx = injectGRPCSpan(ctx, span)
+
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			TraceError(span, err)
+			Finish(span)
+
+			return stream, err
+		}
+
+		return &tracingClientStream{ClientStream: stream, span: span, serverStreams: desc.ServerStreams}, nil
+	}
+}
+
+// tracingClientStream wraps a grpc.ClientStream so the span started by
+// StreamClientInterceptor is finished once the stream is actually done,
+// rather than when the interceptor itself returns.
+type tracingClientStream struct {
+	grpc.ClientStream
+	span opentracing.Span
+
+	// serverStreams mirrors grpc.StreamDesc.ServerStreams. When false, the
+	// RPC delivers exactly one response and a successful RecvMsg is
+	// terminal; when true, the stream keeps going until RecvMsg errors.
+	serverStreams bool
+
+	finishOnce sync.Once
+}
+
+func (s *tracingClientStream) finish(err error) {
+	s.finishOnce.Do(func() {
+		if err != nil && err != io.EOF {
+			TraceError(s.span, err)
+		}
+
+		Finish(s.span)
+	})
+}
+
+func (s *tracingClientStream) SendMsg(m interface{}) error {
+	err := s.ClientStream.SendMsg(m)
+	if err != nil {
+		s.finish(err)
+	}
+
+	return err
+}
+
+func (s *tracingClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		s.finish(err)
+	} else if !s.serverStreams {
+		// Non-server-streaming RPCs (client-streaming or the generated
+		// CloseAndRecv helpers) deliver a single response: this successful
+		// RecvMsg is the terminal event.
+		s.finish(nil)
+	}
+
+	return err
+}
+
+// UnaryServerInterceptor extracts the SpanContext from incoming gRPC
+// metadata (if any) and starts a server span for the handler.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		span, ctx := startGRPCServerSpan(ctx, info.FullMethod)
+		defer Finish(span)
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			TraceError(span, err)
+		}
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor extracts the SpanContext from incoming gRPC
+// metadata (if any) and starts a server span for the handler.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		span, ctx := startGRPCServerSpan(ss.Context(), info.FullMethod)
+		defer Finish(span)
+
+		err := handler(srv, &tracingServerStream{ServerStream: ss, ctx: ctx})
+		if err != nil {
+			TraceError(span, err)
+		}
+
+		return err
+	}
+}
+
+func startGRPCServerSpan(ctx context.Context, fullMethod string) (opentracing.Span, context.Context) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		md = metadata.MD{}
+	}
+
+	spanContext, err := opentracing.GlobalTracer().Extract(opentracing.TextMap, grpcMetadataCarrier(md))
+
+	var span opentracing.Span
+	if err != nil {
+		span = opentracing.StartSpan("grpc " + fullMethod)
+	} else {
+		span = opentracing.StartSpan("grpc "+fullMethod, opentracing.ChildOf(spanContext))
+	}
+
+	ext.SpanKindRPCServer.Set(span)
+
+	return span, opentracing.ContextWithSpan(ctx, span)
+}
+
+func injectGRPCSpan(ctx context.Context, span opentracing.Span) context.Context {
+	if span == nil {
+		return ctx
+	}
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		md = metadata.MD{}
+	} else {
+		md = md.Copy()
+	}
+
+	_ = span.Tracer().Inject(span.Context(), opentracing.TextMap, grpcMetadataCarrier(md))
+
+	ext.SpanKindRPCClient.Set(span)
+
+	return metadata.NewOutgoingContext(ctx, metadata.MD(md))
+}
+
+// tracingServerStream wraps a grpc.ServerStream to surface a context that
+// carries the server span, since grpc.ServerStream does not allow replacing
+// its Context() directly.
+type tracingServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tracingServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,166 @@
+package tracergo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// fakeClientStream is a minimal grpc.ClientStream whose RecvMsg/SendMsg/
+// CloseSend results are scripted per test.
+type fakeClientStream struct {
+	sendErr      error
+	recvErr      error
+	closeSendErr error
+}
+
+func (f *fakeClientStream) Header() (metadata.MD, error) { return nil, nil }
+func (f *fakeClientStream) Trailer() metadata.MD         { return nil }
+func (f *fakeClientStream) Context() context.Context     { return context.Background() }
+func (f *fakeClientStream) CloseSend() error             { return f.closeSendErr }
+func (f *fakeClientStream) SendMsg(m interface{}) error  { return f.sendErr }
+func (f *fakeClientStream) RecvMsg(m interface{}) error  { return f.recvErr }
+
+func newTracingClientStream(t *testing.T, tracer opentracing.Tracer, serverStreams bool, fake *fakeClientStream) (*tracingClientStream, *mocktracer.MockTracer) {
+	t.Helper()
+
+	span := tracer.StartSpan("grpc test")
+
+	return &tracingClientStream{ClientStream: fake, span: span, serverStreams: serverStreams}, tracer.(*mocktracer.MockTracer)
+}
+
+// TestTracingClientStreamClientStreamingFinishesOnRecv reproduces the
+// generated CloseAndRecv pattern (SendMsg..., CloseSend, then a single
+// RecvMsg for the response) and checks the span only finishes once the
+// response actually comes back, not when CloseSend is called.
+func TestTracingClientStreamClientStreamingFinishesOnRecv(t *testing.T) {
+	tracer := mocktracer.New()
+	fake := &fakeClientStream{}
+	stream, tr := newTracingClientStream(t, tracer, false, fake)
+
+	if err := stream.SendMsg("req"); err != nil {
+		t.Fatalf("SendMsg: %v", err)
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("CloseSend: %v", err)
+	}
+
+	if spans := tr.FinishedSpans(); len(spans) != 0 {
+		t.Fatalf("CloseSend must not finish the span, got %d finished spans", len(spans))
+	}
+
+	if err := stream.RecvMsg("resp"); err != nil {
+		t.Fatalf("RecvMsg: %v", err)
+	}
+
+	spans := tr.FinishedSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected span to finish after the response RecvMsg, got %d finished spans", len(spans))
+	}
+
+	if got := spans[0].Tag("error"); got != nil {
+		t.Errorf("error tag = %v, want nil on success", got)
+	}
+}
+
+// TestTracingClientStreamClientStreamingRecordsFinalRecvError reproduces the
+// bug reported in review: CloseSend used to finish (and sync.Once-lock) the
+// span before the final RecvMsg had a chance to report an error, silently
+// dropping that error from the trace.
+func TestTracingClientStreamClientStreamingRecordsFinalRecvError(t *testing.T) {
+	tracer := mocktracer.New()
+	wantErr := errors.New("boom")
+	fake := &fakeClientStream{recvErr: wantErr}
+	stream, tr := newTracingClientStream(t, tracer, false, fake)
+
+	_ = stream.CloseSend()
+
+	if spans := tr.FinishedSpans(); len(spans) != 0 {
+		t.Fatalf("CloseSend must not finish the span, got %d finished spans", len(spans))
+	}
+
+	if err := stream.RecvMsg("resp"); err != wantErr {
+		t.Fatalf("RecvMsg() = %v, want %v", err, wantErr)
+	}
+
+	spans := tr.FinishedSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected span to finish after RecvMsg error, got %d finished spans", len(spans))
+	}
+
+	if got := spans[0].Tag("error"); got != "true" {
+		t.Errorf("error tag = %v, want %q", got, "true")
+	}
+}
+
+func TestTracingClientStreamServerStreamingFinishesOnTerminalError(t *testing.T) {
+	tracer := mocktracer.New()
+	fake := &fakeClientStream{recvErr: io.EOF}
+	stream, tr := newTracingClientStream(t, tracer, true, fake)
+
+	if err := stream.RecvMsg("resp"); err != io.EOF {
+		t.Fatalf("RecvMsg() = %v, want io.EOF", err)
+	}
+
+	spans := tr.FinishedSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected span to finish on io.EOF, got %d finished spans", len(spans))
+	}
+
+	if got := spans[0].Tag("error"); got != nil {
+		t.Errorf("error tag = %v, want nil for io.EOF", got)
+	}
+}
+
+func TestTracingClientStreamFinishIsIdempotent(t *testing.T) {
+	tracer := mocktracer.New()
+	fake := &fakeClientStream{recvErr: io.EOF}
+	stream, tr := newTracingClientStream(t, tracer, true, fake)
+
+	_ = stream.RecvMsg("resp")
+	_ = stream.RecvMsg("resp")
+	_ = stream.CloseSend()
+
+	if spans := tr.FinishedSpans(); len(spans) != 1 {
+		t.Fatalf("expected exactly 1 finished span, got %d", len(spans))
+	}
+}
+
+func TestStreamClientInterceptorPropagatesServerStreamsFlag(t *testing.T) {
+	prev := opentracing.GlobalTracer()
+	tracer := mocktracer.New()
+	opentracing.SetGlobalTracer(tracer)
+	defer opentracing.SetGlobalTracer(prev)
+
+	fake := &fakeClientStream{}
+	interceptor := StreamClientInterceptor()
+
+	stream, err := interceptor(
+		context.Background(),
+		&grpc.StreamDesc{ServerStreams: false},
+		nil,
+		"/svc/Method",
+		func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return fake, nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("interceptor: %v", err)
+	}
+
+	tcs, ok := stream.(*tracingClientStream)
+	if !ok {
+		t.Fatalf("expected *tracingClientStream, got %T", stream)
+	}
+
+	if tcs.serverStreams {
+		t.Errorf("serverStreams = true, want false for a client-streaming StreamDesc")
+	}
+}
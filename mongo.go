@@ -0,0 +1,70 @@
+package tracergo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/opentracing/opentracing-go"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// NewMongoCommandMonitor builds an event.CommandMonitor that starts a span
+// for every command mongo-go-driver sends and finishes it with the result,
+// tagged with db.type and a redacted db.statement. Spans are correlated
+// across the Started/Succeeded/Failed callbacks by the driver's RequestID.
+// db.statement is the command's extended JSON passed through
+// redactSQLStatement, which replaces digit runs but keeps double-quoted
+// string values as they are.
+//
+//	client, err := mongo.Connect(ctx, options.Client().SetMonitor(tracergo.NewMongoCommandMonitor()))
+func NewMongoCommandMonitor() *event.CommandMonitor {
+	m := &mongoMonitor{spans: map[int64]opentracing.Span{}}
+
+	return &event.CommandMonitor{
+		Started:   m.started,
+		Succeeded: m.succeeded,
+		Failed:    m.failed,
+	}
+}
+
+type mongoMonitor struct {
+	mu    sync.Mutex
+	spans map[int64]opentracing.Span
+}
+
+func (m *mongoMonitor) started(ctx context.Context, evt *event.CommandStartedEvent) {
+	span, _ := StartDBSpan(ctx, evt.CommandName)
+	if span == nil {
+		return
+	}
+
+	AddTag(span, "db.type", "mongo")
+	AddTag(span, "db.instance", evt.DatabaseName)
+	AddTag(span, "db.statement", redactSQLStatement(evt.Command.String()))
+
+	m.mu.Lock()
+	m.spans[evt.RequestID] = span
+	m.mu.Unlock()
+}
+
+func (m *mongoMonitor) succeeded(_ context.Context, evt *event.CommandSucceededEvent) {
+	Finish(m.popSpan(evt.RequestID))
+}
+
+func (m *mongoMonitor) failed(_ context.Context, evt *event.CommandFailedEvent) {
+	span := m.popSpan(evt.RequestID)
+
+	TraceError(span, fmt.Errorf("%s", evt.Failure))
+	Finish(span)
+}
+
+func (m *mongoMonitor) popSpan(requestID int64) opentracing.Span {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	span := m.spans[requestID]
+	delete(m.spans, requestID)
+
+	return span
+}
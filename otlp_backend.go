@@ -0,0 +1,191 @@
+package tracergo
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/sirupsen/logrus"
+	"github.com/uber/jaeger-client-go"
+)
+
+// OTLPBackend exports spans to an OpenTelemetry collector's OTLP/HTTP
+// endpoint (e.g. "https://otel-collector:4318/v1/traces"), letting teams
+// migrate off Jaeger without touching call sites: spans are still produced
+// by the jaeger tracer, this backend only changes where they are shipped.
+type OTLPBackend struct {
+	Endpoint  string
+	TLSConfig *tls.Config
+	Headers   map[string]string
+	Timeout   time.Duration
+}
+
+func (b OTLPBackend) Reporter(serviceName string) (jaeger.Reporter, error) {
+	if b.Endpoint == "" {
+		return nil, fmt.Errorf("otlp backend: Endpoint is required")
+	}
+
+	timeout := b.Timeout
+	if timeout == 0 {
+		timeout = 5 * time.Second
+	}
+
+	client := &http.Client{Timeout: timeout}
+	if b.TLSConfig != nil {
+		client.Transport = &http.Transport{TLSClientConfig: b.TLSConfig}
+	}
+
+	r := &otlpReporter{
+		serviceName: serviceName,
+		endpoint:    b.Endpoint,
+		headers:     b.Headers,
+		client:      client,
+	}
+	r.async = newAsyncBatchReporter(asyncReporterBufferSize, asyncReporterFlushInterval, r.export)
+
+	return r, nil
+}
+
+// otlpResourceSpans is the minimal subset of the OTLP/HTTP JSON span model
+// we fill in from a reported jaeger.Span.
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpReporter struct {
+	serviceName string
+	endpoint    string
+	headers     map[string]string
+	client      *http.Client
+	async       *asyncBatchReporter
+}
+
+// Report hands the span to the asyncBatchReporter so the actual HTTP export
+// happens off the caller's goroutine.
+func (r *otlpReporter) Report(span *jaeger.Span) {
+	r.async.Report(span)
+}
+
+func (r *otlpReporter) Close() {
+	r.async.Close()
+}
+
+// export POSTs a batch of spans to the OTLP/HTTP collector. It runs on the
+// asyncBatchReporter's background goroutine, never on the goroutine that
+// called span.Finish().
+func (r *otlpReporter) export(spans []*jaeger.Span) {
+	otlpSpans := make([]otlpSpan, 0, len(spans))
+
+	for _, span := range spans {
+		ctx := span.SpanContext()
+		end := span.StartTime().Add(span.Duration())
+
+		otlpSpans = append(otlpSpans, otlpSpan{
+			TraceID:           fmt.Sprintf("%016x%016x", ctx.TraceID().High, ctx.TraceID().Low),
+			SpanID:            ctx.SpanID().String(),
+			ParentSpanID:      parentIDString(ctx),
+			Name:              span.OperationName(),
+			StartTimeUnixNano: fmt.Sprintf("%d", span.StartTime().UnixNano()),
+			EndTimeUnixNano:   fmt.Sprintf("%d", end.UnixNano()),
+			Attributes:        tagsToOTLPAttributes(span.Tags()),
+		})
+	}
+
+	payload := struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}{
+		ResourceSpans: []otlpResourceSpans{
+			{
+				Resource: otlpResource{
+					Attributes: []otlpKeyValue{
+						{Key: "service.name", Value: otlpAnyValue{StringValue: r.serviceName}},
+					},
+				},
+				ScopeSpans: []otlpScopeSpans{
+					{Spans: otlpSpans},
+				},
+			},
+		},
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		logrus.Warnf("otlp backend: failed to marshal spans: %s", err.Error())
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodPost, r.endpoint, bytes.NewReader(body))
+	if err != nil {
+		logrus.Warnf("otlp backend: failed to build request: %s", err.Error())
+		return
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range r.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		logrus.Warnf("otlp backend: failed to export spans: %s", err.Error())
+		return
+	}
+
+	_ = resp.Body.Close()
+}
+
+func parentIDString(ctx jaeger.SpanContext) string {
+	if ctx.ParentID() == 0 {
+		return ""
+	}
+
+	return ctx.ParentID().String()
+}
+
+func tagsToOTLPAttributes(tags opentracing.Tags) []otlpKeyValue {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	out := make([]otlpKeyValue, 0, len(tags))
+	for k, v := range tags {
+		out = append(out, otlpKeyValue{
+			Key:   k,
+			Value: otlpAnyValue{StringValue: fmt.Sprintf("%v", v)},
+		})
+	}
+
+	return out
+}
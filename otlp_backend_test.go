@@ -0,0 +1,28 @@
+package tracergo
+
+import (
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/uber/jaeger-client-go"
+)
+
+func TestTagsToOTLPAttributes(t *testing.T) {
+	tags := opentracing.Tags{"db.instance": "orders"}
+
+	got := tagsToOTLPAttributes(tags)
+	if len(got) != 1 || got[0].Key != "db.instance" || got[0].Value.StringValue != "orders" {
+		t.Errorf("tagsToOTLPAttributes(%v) = %v, want [{db.instance orders}]", tags, got)
+	}
+
+	if got := tagsToOTLPAttributes(nil); got != nil {
+		t.Errorf("tagsToOTLPAttributes(nil) = %v, want nil", got)
+	}
+}
+
+func TestParentIDString(t *testing.T) {
+	var root jaeger.SpanContext // zero value has ParentID() == 0
+	if got := parentIDString(root); got != "" {
+		t.Errorf("parentIDString(root span) = %q, want empty", got)
+	}
+}
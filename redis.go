@@ -0,0 +1,78 @@
+package tracergo
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/opentracing/opentracing-go"
+)
+
+// redisSpanContextKey stores the span started for a command in the context
+// BeforeProcess returns, so AfterProcess can finish the same span.
+type redisSpanContextKey struct{}
+
+// RedisHook implements redis.Hook (go-redis v8) so that every command and
+// pipeline executed through a *redis.Client gets its own span tagged with
+// db.type and a redacted db.statement, without call sites changing. A
+// command's db.statement is cmd.String() passed through redactSQLStatement,
+// which only replaces single-quoted strings and standalone digit runs, so
+// other key and value arguments are kept as they are.
+//
+//	rdb := redis.NewClient(opts)
+//	rdb.AddHook(tracergo.RedisHook{})
+type RedisHook struct{}
+
+func (RedisHook) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	span, newCtx := StartDBSpan(ctx, cmd.Name())
+	if span == nil {
+		return ctx, nil
+	}
+
+	AddTag(span, "db.type", "redis")
+	AddTag(span, "db.statement", redactSQLStatement(cmd.String()))
+
+	return context.WithValue(newCtx, redisSpanContextKey{}, span), nil
+}
+
+func (RedisHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	span, _ := ctx.Value(redisSpanContextKey{}).(opentracing.Span)
+	defer Finish(span)
+
+	if err := cmd.Err(); err != nil && err != redis.Nil {
+		TraceError(span, err)
+	}
+
+	return nil
+}
+
+func (RedisHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	names := make([]string, 0, len(cmds))
+	for _, cmd := range cmds {
+		names = append(names, cmd.Name())
+	}
+
+	span, newCtx := StartDBSpan(ctx, "pipeline")
+	if span == nil {
+		return ctx, nil
+	}
+
+	AddTag(span, "db.type", "redis")
+	AddTag(span, "db.statement", strings.Join(names, ", "))
+
+	return context.WithValue(newCtx, redisSpanContextKey{}, span), nil
+}
+
+func (RedisHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	span, _ := ctx.Value(redisSpanContextKey{}).(opentracing.Span)
+	defer Finish(span)
+
+	for _, cmd := range cmds {
+		if err := cmd.Err(); err != nil && err != redis.Nil {
+			TraceError(span, err)
+			break
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package tracergo
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/uber/jaeger-client-go"
+)
+
+// SamplerType selects the jaeger.Sampler strategy built from a
+// SamplerConfig.
+type SamplerType string
+
+const (
+	// SamplerConst samples every span (Param != 0) or none (Param == 0).
+	SamplerConst SamplerType = "const"
+	// SamplerProbabilistic samples a fraction of spans given by Param, in [0.0, 1.0].
+	SamplerProbabilistic SamplerType = "probabilistic"
+	// SamplerRateLimiting samples at most Param traces per second.
+	SamplerRateLimiting SamplerType = "ratelimiting"
+	// SamplerRemote polls the jaeger-agent's /sampling endpoint for the
+	// strategy to use, including per-operation adaptive sampling, and swaps
+	// it in at runtime as it changes.
+	SamplerRemote SamplerType = "remote"
+)
+
+const defaultMaxOperations = 200
+
+// SamplerConfig configures the sampling strategy installed on the tracer
+// built by Init. The zero value samples every span, matching the
+// hard-coded behavior InitGlobalTracer used to have.
+type SamplerConfig struct {
+	Type SamplerType
+	// Param means different things depending on Type: sample-or-not for
+	// const, fraction for probabilistic, max traces/sec for ratelimiting.
+	// Unused for remote.
+	Param float64
+	// AgentHost is the jaeger-agent host:port whose /sampling?service=...
+	// endpoint is polled when Type is SamplerRemote.
+	AgentHost string
+	// RefreshInterval controls how often the remote sampler re-polls
+	// AgentHost. Defaults to 1 minute.
+	RefreshInterval time.Duration
+	// MaxOperations bounds how many distinct per-operation samplers the
+	// remote sampler caches before falling back to the default
+	// probabilistic sampler for further operations. Defaults to 200.
+	MaxOperations int
+}
+
+func buildSampler(serviceName string, cfg SamplerConfig) (jaeger.Sampler, error) {
+	switch cfg.Type {
+	case "", SamplerConst:
+		param := cfg.Param
+		if cfg.Type == "" {
+			param = 1 // back-compat: InitGlobalTracer always sampled everything.
+		}
+
+		return jaeger.NewConstSampler(param != 0), nil
+	case SamplerProbabilistic:
+		sampler, err := jaeger.NewProbabilisticSampler(cfg.Param)
+		if err != nil {
+			return nil, fmt.Errorf("probabilistic sampler: %w", err)
+		}
+
+		return sampler, nil
+	case SamplerRateLimiting:
+		return jaeger.NewRateLimitingSampler(cfg.Param), nil
+	case SamplerRemote:
+		if cfg.AgentHost == "" {
+			return nil, fmt.Errorf("remote sampler: AgentHost is required")
+		}
+
+		maxOperations := cfg.MaxOperations
+		if maxOperations == 0 {
+			maxOperations = defaultMaxOperations
+		}
+
+		refreshInterval := cfg.RefreshInterval
+		if refreshInterval == 0 {
+			refreshInterval = time.Minute
+		}
+
+		return jaeger.NewRemotelyControlledSampler(
+			serviceName,
+			jaeger.SamplerOptions.SamplingServerURL("http://"+cfg.AgentHost+"/sampling"),
+			jaeger.SamplerOptions.MaxOperations(maxOperations),
+			jaeger.SamplerOptions.SamplingRefreshInterval(refreshInterval),
+		), nil
+	default:
+		return nil, fmt.Errorf("unknown sampler type %q", cfg.Type)
+	}
+}
@@ -0,0 +1,40 @@
+package tracergo
+
+import "testing"
+
+func TestBuildSampler(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     SamplerConfig
+		wantErr bool
+	}{
+		{name: "zero value defaults to const sampling everything", cfg: SamplerConfig{}},
+		{name: "const", cfg: SamplerConfig{Type: SamplerConst, Param: 1}},
+		{name: "probabilistic", cfg: SamplerConfig{Type: SamplerProbabilistic, Param: 0.5}},
+		{name: "probabilistic invalid param", cfg: SamplerConfig{Type: SamplerProbabilistic, Param: 2}, wantErr: true},
+		{name: "ratelimiting", cfg: SamplerConfig{Type: SamplerRateLimiting, Param: 10}},
+		{name: "remote missing AgentHost", cfg: SamplerConfig{Type: SamplerRemote}, wantErr: true},
+		{name: "remote", cfg: SamplerConfig{Type: SamplerRemote, AgentHost: "localhost:5778"}},
+		{name: "unknown type", cfg: SamplerConfig{Type: "bogus"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sampler, err := buildSampler("test-service", tt.cfg)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("buildSampler(%+v) returned no error, want one", tt.cfg)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("buildSampler(%+v) returned unexpected error: %s", tt.cfg, err)
+			}
+
+			if sampler == nil {
+				t.Fatalf("buildSampler(%+v) returned a nil sampler", tt.cfg)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package tracergo
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+
+	"github.com/opentracing/opentracing-go"
+)
+
+var sqlLiteralPattern = regexp.MustCompile(`'(?:[^'\\]|\\.)*'|\b\d+\b`)
+
+// redactSQLStatement strips string and numeric literals from a SQL
+// statement before it is attached to a span, so that query parameters
+// (which often carry PII) are never shipped to the tracing backend.
+func redactSQLStatement(query string) string {
+	return sqlLiteralPattern.ReplaceAllString(query, "?")
+}
+
+// WrapDriver wraps an existing database/sql/driver.Driver so that every
+// QueryContext/ExecContext/BeginTx/Commit/Rollback it performs gets its own
+// span tagged with db.type, db.instance and a redacted db.statement.
+// Register the result once under a new driver name, e.g.:
+//
+//	sql.Register("tracer-mysql", tracergo.WrapDriver(mysql.MySQLDriver{}))
+//	db, err := sql.Open("tracer-mysql", dsn)
+func WrapDriver(d driver.Driver) driver.Driver {
+	return &tracingDriver{driver: d, dbType: fmt.Sprintf("%T", d)}
+}
+
+type tracingDriver struct {
+	driver driver.Driver
+	dbType string
+}
+
+func (d *tracingDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tracingConn{conn: conn, dbType: d.dbType, dbInstance: name}, nil
+}
+
+type tracingConn struct {
+	conn       driver.Conn
+	dbType     string
+	dbInstance string
+}
+
+func (c *tracingConn) Prepare(query string) (driver.Stmt, error) {
+	stmt, err := c.conn.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tracingStmt{stmt: stmt, conn: c, query: query}, nil
+}
+
+func (c *tracingConn) Close() error {
+	return c.conn.Close()
+}
+
+func (c *tracingConn) Begin() (driver.Tx, error) { //nolint:staticcheck // required by driver.Conn
+	tx, err := c.conn.Begin() //nolint:staticcheck
+	if err != nil {
+		return nil, err
+	}
+
+	return &tracingTx{tx: tx, conn: c, ctx: context.Background()}, nil
+}
+
+func (c *tracingConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	span, spanCtx := c.startSpan(ctx, "BeginTx", "")
+	defer Finish(span)
+
+	beginner, ok := c.conn.(driver.ConnBeginTx)
+	if !ok {
+		tx, err := c.conn.Begin() //nolint:staticcheck
+		if err != nil {
+			TraceError(span, err)
+			return nil, err
+		}
+
+		return &tracingTx{tx: tx, conn: c, ctx: spanCtx}, nil
+	}
+
+	tx, err := beginner.BeginTx(ctx, opts)
+	if err != nil {
+		TraceError(span, err)
+		return nil, err
+	}
+
+	return &tracingTx{tx: tx, conn: c, ctx: spanCtx}, nil
+}
+
+func (c *tracingConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	span, _ := c.startSpan(ctx, "QueryContext", query)
+	defer Finish(span)
+
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if err != nil {
+		TraceError(span, err)
+	}
+
+	return rows, err
+}
+
+func (c *tracingConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	span, _ := c.startSpan(ctx, "ExecContext", query)
+	defer Finish(span)
+
+	result, err := execer.ExecContext(ctx, query, args)
+	if err != nil {
+		TraceError(span, err)
+	}
+
+	return result, err
+}
+
+func (c *tracingConn) startSpan(ctx context.Context, operation, query string) (opentracing.Span, context.Context) {
+	span, newCtx := StartDBSpan(ctx, operation)
+	if span == nil {
+		return nil, newCtx
+	}
+
+	AddTag(span, "db.type", c.dbType)
+	AddTag(span, "db.instance", c.dbInstance)
+
+	if query != "" {
+		AddTag(span, "db.statement", redactSQLStatement(query))
+	}
+
+	return span, newCtx
+}
+
+type tracingStmt struct {
+	stmt  driver.Stmt
+	conn  *tracingConn
+	query string
+}
+
+func (s *tracingStmt) Close() error {
+	return s.stmt.Close()
+}
+
+func (s *tracingStmt) NumInput() int {
+	return s.stmt.NumInput()
+}
+
+func (s *tracingStmt) Exec(args []driver.Value) (driver.Result, error) { //nolint:staticcheck
+	return s.stmt.Exec(args) //nolint:staticcheck
+}
+
+func (s *tracingStmt) Query(args []driver.Value) (driver.Rows, error) { //nolint:staticcheck
+	return s.stmt.Query(args) //nolint:staticcheck
+}
+
+func (s *tracingStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := s.stmt.(driver.StmtExecContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	span, _ := s.conn.startSpan(ctx, "StmtExec", s.query)
+	defer Finish(span)
+
+	result, err := execer.ExecContext(ctx, args)
+	if err != nil {
+		TraceError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *tracingStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := s.stmt.(driver.StmtQueryContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	span, _ := s.conn.startSpan(ctx, "StmtQuery", s.query)
+	defer Finish(span)
+
+	rows, err := queryer.QueryContext(ctx, args)
+	if err != nil {
+		TraceError(span, err)
+	}
+
+	return rows, err
+}
+
+type tracingTx struct {
+	tx   driver.Tx
+	conn *tracingConn
+	ctx  context.Context
+}
+
+func (t *tracingTx) Commit() error {
+	span, _ := t.conn.startSpan(t.ctx, "Commit", "")
+	defer Finish(span)
+
+	if err := t.tx.Commit(); err != nil {
+		TraceError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (t *tracingTx) Rollback() error {
+	span, _ := t.conn.startSpan(t.ctx, "Rollback", "")
+	defer Finish(span)
+
+	if err := t.tx.Rollback(); err != nil {
+		TraceError(span, err)
+		return err
+	}
+
+	return nil
+}
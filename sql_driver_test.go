@@ -0,0 +1,122 @@
+package tracergo
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+type fakeTx struct {
+	commitErr   error
+	rollbackErr error
+}
+
+func (t *fakeTx) Commit() error   { return t.commitErr }
+func (t *fakeTx) Rollback() error { return t.rollbackErr }
+
+type fakeConn struct {
+	tx *fakeTx
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *fakeConn) Close() error                              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)                 { return c.tx, nil } //nolint:staticcheck
+
+func (c *fakeConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.tx, nil
+}
+
+type fakeDriver struct {
+	conn *fakeConn
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) { return d.conn, nil }
+
+// TestWrapDriverParentsCommitAndRollbackOnTransactionSpan reproduces the bug
+// reported in review: the Commit span must be a child of the span started
+// by BeginTx, not a disconnected root span.
+func TestWrapDriverParentsCommitOnTransactionSpan(t *testing.T) {
+	tracer := mocktracer.New()
+	prev := opentracing.GlobalTracer()
+	opentracing.SetGlobalTracer(tracer)
+	defer opentracing.SetGlobalTracer(prev)
+
+	conn := &fakeConn{tx: &fakeTx{}}
+	wrapped := WrapDriver(&fakeDriver{conn: conn})
+
+	drvConn, err := wrapped.Open("dsn")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	beginner, ok := drvConn.(driver.ConnBeginTx)
+	if !ok {
+		t.Fatalf("tracingConn does not implement driver.ConnBeginTx")
+	}
+
+	tx, err := beginner.BeginTx(context.Background(), driver.TxOptions{})
+	if err != nil {
+		t.Fatalf("BeginTx: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	spans := tracer.FinishedSpans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 finished spans (BeginTx, Commit), got %d", len(spans))
+	}
+
+	beginSpan, commitSpan := spans[0], spans[1]
+	if beginSpan.OperationName != "DB-BeginTx" {
+		t.Fatalf("spans[0].OperationName = %q, want DB-BeginTx", beginSpan.OperationName)
+	}
+
+	if commitSpan.OperationName != "DB-Commit" {
+		t.Fatalf("spans[1].OperationName = %q, want DB-Commit", commitSpan.OperationName)
+	}
+
+	if commitSpan.ParentID != beginSpan.SpanContext.SpanID {
+		t.Errorf("Commit span ParentID = %d, want %d (BeginTx span)", commitSpan.ParentID, beginSpan.SpanContext.SpanID)
+	}
+}
+
+func TestWrapDriverParentsRollbackOnTransactionSpan(t *testing.T) {
+	tracer := mocktracer.New()
+	prev := opentracing.GlobalTracer()
+	opentracing.SetGlobalTracer(tracer)
+	defer opentracing.SetGlobalTracer(prev)
+
+	conn := &fakeConn{tx: &fakeTx{}}
+	wrapped := WrapDriver(&fakeDriver{conn: conn})
+
+	drvConn, err := wrapped.Open("dsn")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	beginner := drvConn.(driver.ConnBeginTx)
+
+	tx, err := beginner.BeginTx(context.Background(), driver.TxOptions{})
+	if err != nil {
+		t.Fatalf("BeginTx: %v", err)
+	}
+
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+
+	spans := tracer.FinishedSpans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 finished spans (BeginTx, Rollback), got %d", len(spans))
+	}
+
+	beginSpan, rollbackSpan := spans[0], spans[1]
+	if rollbackSpan.ParentID != beginSpan.SpanContext.SpanID {
+		t.Errorf("Rollback span ParentID = %d, want %d (BeginTx span)", rollbackSpan.ParentID, beginSpan.SpanContext.SpanID)
+	}
+}
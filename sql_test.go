@@ -0,0 +1,40 @@
+package tracergo
+
+import "testing"
+
+func TestRedactSQLStatement(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "string literal",
+			query: "SELECT * FROM users WHERE email = 'alice@example.com'",
+			want:  "SELECT * FROM users WHERE email = ?",
+		},
+		{
+			name:  "numeric literal",
+			query: "SELECT * FROM orders WHERE id = 42",
+			want:  "SELECT * FROM orders WHERE id = ?",
+		},
+		{
+			name:  "escaped quote inside string literal",
+			query: `UPDATE users SET name = 'O\'Brien' WHERE id = 7`,
+			want:  "UPDATE users SET name = ? WHERE id = ?",
+		},
+		{
+			name:  "no literals",
+			query: "SELECT * FROM users",
+			want:  "SELECT * FROM users",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redactSQLStatement(tt.query); got != tt.want {
+				t.Errorf("redactSQLStatement(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
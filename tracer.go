@@ -5,7 +5,6 @@ import (
 	"io"
 	"net/http"
 	"strings"
-	"time"
 
 	"github.com/emicklei/go-restful"
 	"github.com/opentracing/opentracing-go"
@@ -13,8 +12,6 @@ import (
 	"github.com/opentracing/opentracing-go/log"
 	"github.com/sirupsen/logrus"
 	"github.com/uber/jaeger-client-go"
-	"github.com/uber/jaeger-client-go/transport"
-	"github.com/uber/jaeger-client-go/zipkin"
 )
 
 type contextKeyType string
@@ -34,58 +31,30 @@ var forwardHeaders = [...]string{
 
 // InitGlobalTracer initialize global tracer
 // Must be called in main function
+//
+// Deprecated: use Init with a Config so that the tracing backend and
+// sampling strategy can be configured; this wrapper always reports to a
+// single Jaeger agent/collector with 100% sampling.
 func InitGlobalTracer(
 	jaegerAgentHost string,
 	jaegerCollectorEndpoint string,
 	serviceName string,
 	realm string,
 ) io.Closer {
-	zipkinPropagator := zipkin.NewZipkinB3HTTPHeaderPropagator()
-	injector := jaeger.TracerOptions.Injector(opentracing.HTTPHeaders, zipkinPropagator)
-	extractor := jaeger.TracerOptions.Extractor(opentracing.HTTPHeaders, zipkinPropagator)
-
-	// Zipkin shares span ID between client and server spans; it must be enabled via the following option.
-	zipkinSharedRPCSpan := jaeger.TracerOptions.ZipkinSharedRPCSpan(true)
-
-	var reporter jaeger.Reporter
-
-	if jaegerAgentHost == "" && jaegerCollectorEndpoint == "" {
-		reporter = jaeger.NewNullReporter() // for running locally
-
-		logrus.Info("Jaeger client configured to be silent")
-	} else {
-		var sender jaeger.Transport
-		if jaegerCollectorEndpoint != "" {
-			sender = transport.NewHTTPTransport(jaegerCollectorEndpoint)
-			logrus.Infof("Jaeger client configured to use the collector: %s", jaegerCollectorEndpoint)
-		} else {
-			var err error
-			sender, err = jaeger.NewUDPTransport(jaegerAgentHost, 0)
-			if err != nil {
-				logrus.Errorf("Jaeger transport initialization error: %s", err.Error())
-			}
-			logrus.Infof("Jaeger client configured to use the agent: %s", jaegerAgentHost)
-		}
-
-		reporter = jaeger.NewRemoteReporter(
-			sender,
-			jaeger.ReporterOptions.BufferFlushInterval(1*time.Second),
-			jaeger.ReporterOptions.Logger(jaeger.StdLogger),
-		)
+	_, closer, err := Init(Config{
+		ServiceName: serviceName,
+		Realm:       realm,
+		Backends: []Backend{
+			JaegerBackend{
+				AgentHost:         jaegerAgentHost,
+				CollectorEndpoint: jaegerCollectorEndpoint,
+			},
+		},
+	})
+	if err != nil {
+		logrus.Errorf("tracergo: %s", err.Error())
 	}
 
-	newTracer, closer := jaeger.NewTracer(
-		serviceName+"."+realm,
-		jaeger.NewConstSampler(true),
-		reporter,
-		injector,
-		extractor,
-		zipkinSharedRPCSpan,
-		jaeger.TracerOptions.PoolSpans(false),
-	)
-	// Set the singleton opentracing.Tracer with the Jaeger tracer.
-	opentracing.SetGlobalTracer(newTracer)
-
 	return closer
 }
 
@@ -171,6 +140,8 @@ func StartSpan(req *restful.Request, operationName string) (opentracing.Span, co
 		AddTag(span, TraceIDKey, abTraceID)
 	}
 
+	tagWellKnownBaggage(span)
+
 	return span, opentracing.ContextWithSpan(req.Request.Context(), span)
 }
 
@@ -208,6 +179,8 @@ func StartSpanIfParentSpanExist(req *restful.Request, operationName string) (ope
 		AddTag(span, TraceIDKey, abTraceID)
 	}
 
+	tagWellKnownBaggage(span)
+
 	return span, opentracing.ContextWithSpan(req.Request.Context(), span)
 }
 
@@ -218,10 +191,13 @@ func ChildSpanFromRemoteSpan(
 ) (opentracing.Span, context.Context) {
 	spanContext, err := jaeger.ContextFromString(spanContextStr)
 	if err == nil {
-		return opentracing.StartSpan(
+		span := opentracing.StartSpan(
 			name,
 			opentracing.ChildOf(spanContext),
-		), rootCtx
+		)
+		tagWellKnownBaggage(span)
+
+		return span, rootCtx
 	}
 
 	return StartSpanFromContext(rootCtx, name)
@@ -240,6 +216,8 @@ func StartDBSpan(ctx context.Context, operationName string) (opentracing.Span, c
 func StartSpanFromContext(ctx context.Context, operationName string) (opentracing.Span, context.Context) {
 	if ctx != nil {
 		childSpan, childCtx := opentracing.StartSpanFromContext(ctx, operationName)
+		tagWellKnownBaggage(childSpan)
+
 		return childSpan, childCtx
 	}
 
@@ -248,10 +226,13 @@ func StartSpanFromContext(ctx context.Context, operationName string) (opentracin
 
 func StartChildSpan(span opentracing.Span, name string) opentracing.Span {
 	if span != nil {
-		return opentracing.StartSpan(
+		childSpan := opentracing.StartSpan(
 			name,
 			opentracing.ChildOf(span.Context()),
 		)
+		tagWellKnownBaggage(childSpan)
+
+		return childSpan
 	}
 
 	return nil
@@ -299,23 +280,6 @@ func AddTag(span opentracing.Span, key string, value string) {
 	}
 }
 
-// AddBaggage to add baggage in span if span is valid
-// sets a key:value pair on this Span and its SpanContext
-// that also propagates to descendants of this Span.
-func AddBaggage(span opentracing.Span, key string, value string) {
-	if span != nil {
-		span.SetBaggageItem(key, value)
-	}
-}
-
-// TraceError sends a log and a tag with Error into tracer
-func TraceError(span opentracing.Span, err error) {
-	if span != nil && err != nil {
-		AddLog(span, "error", err.Error())
-		AddTag(span, "error", "true")
-	}
-}
-
 // TraceSQLQuery sends a log with SQL query into tracer
 func TraceSQLQuery(span opentracing.Span, query string) {
 	if span != nil && query != "" {
@@ -323,13 +287,13 @@ func TraceSQLQuery(span opentracing.Span, query string) {
 	}
 }
 
-// GetSpanFromRestfulContext get crated by jaeger Filter span from the context
+// GetSpanFromRestfulContext get the span created by Filter from the context
 func GetSpanFromRestfulContext(ctx context.Context) opentracing.Span {
 	if span, ok := ctx.Value(SpanContextKey).(opentracing.Span); ok {
 		return span
 	}
 
-	logrus.Info("missed initialization of restful plugin jaeger.Filter")
+	logrus.Info("missed initialization of restful plugin tracergo.Filter")
 
 	span, _ := StartSpanFromContext(ctx, "unnamed")
 
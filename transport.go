@@ -0,0 +1,98 @@
+package tracergo
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/emicklei/go-restful"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+)
+
+const forwardedHeadersContextKey = contextKeyType("forwardedHeaders")
+
+// contextWithForwardedHeaders records the subset of the inbound request's
+// headers (forwardHeaders and TraceIDKey) that NewTransport should replay
+// onto any outgoing request made from the resulting context, the same set
+// InjectTrace already copies by hand.
+func contextWithForwardedHeaders(ctx context.Context, req *restful.Request) context.Context {
+	headers := make(map[string]string)
+
+	for _, header := range forwardHeaders {
+		if value := req.Request.Header.Get(header); value != "" {
+			headers[header] = value
+		}
+	}
+
+	if traceID := req.Request.Header.Get(TraceIDKey); traceID != "" {
+		headers[TraceIDKey] = traceID
+	}
+
+	if len(headers) == 0 {
+		return ctx
+	}
+
+	return context.WithValue(ctx, forwardedHeadersContextKey, headers)
+}
+
+// NewTransport wraps base (http.DefaultTransport if nil) so that every
+// request made through it starts a child "HTTP <method> <host>" span from
+// the span active on the request's context, injects it into the outgoing
+// headers alongside the forwardHeaders/TraceIDKey captured by Filter, and
+// records the response status/error before finishing the span. Install it
+// as an http.Client's Transport to stop opening/closing spans by hand
+// around every outgoing call.
+func NewTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &tracingTransport{base: base}
+}
+
+type tracingTransport struct {
+	base http.RoundTripper
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	parent := opentracing.SpanFromContext(req.Context())
+	if parent == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	span := StartChildSpan(parent, "HTTP "+req.Method+" "+req.Host)
+	defer Finish(span)
+
+	ext.HTTPMethod.Set(span, req.Method)
+	ext.HTTPUrl.Set(span, req.Host+req.URL.RequestURI())
+
+	// RoundTrip must not mutate the request it is given, so inject headers
+	// into a clone (Clone deep-copies Header too) rather than the caller's.
+	req = req.Clone(req.Context())
+
+	if headers, ok := req.Context().Value(forwardedHeadersContextKey).(map[string]string); ok {
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+	}
+
+	_ = span.Tracer().Inject(
+		span.Context(),
+		opentracing.HTTPHeaders,
+		opentracing.HTTPHeadersCarrier(req.Header),
+	)
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		TraceError(span, err)
+		return resp, err
+	}
+
+	ext.HTTPStatusCode.Set(span, uint16(resp.StatusCode))
+
+	if resp.StatusCode >= 500 {
+		AddTag(span, "error", "true")
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,127 @@
+package tracergo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+type fakeRoundTripper struct {
+	resp   *http.Response
+	err    error
+	gotReq *http.Request
+}
+
+func (f *fakeRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.gotReq = req
+	return f.resp, f.err
+}
+
+func newTracedRequest(t *testing.T, tracer opentracing.Tracer) *http.Request {
+	t.Helper()
+
+	span := tracer.StartSpan("parent")
+	ctx := opentracing.ContextWithSpan(context.Background(), span)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.com/path", nil)
+	if err != nil {
+		t.Fatalf("NewRequestWithContext: %v", err)
+	}
+
+	return req
+}
+
+func TestTracingTransportDoesNotMutateCallersRequest(t *testing.T) {
+	tracer := mocktracer.New()
+	prev := opentracing.GlobalTracer()
+	opentracing.SetGlobalTracer(tracer)
+	defer opentracing.SetGlobalTracer(prev)
+
+	base := &fakeRoundTripper{resp: &http.Response{StatusCode: http.StatusOK}}
+	transport := NewTransport(base)
+
+	req := newTracedRequest(t, tracer)
+	req = req.WithContext(context.WithValue(req.Context(), forwardedHeadersContextKey, map[string]string{"X-Tenant-Id": "acme"}))
+
+	origHeader := req.Header
+
+	if _, err := transport.RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+
+	if len(origHeader) != 0 {
+		t.Errorf("caller's request.Header mutated: %v", origHeader)
+	}
+
+	if base.gotReq.Header.Get("X-Tenant-Id") != "acme" {
+		t.Errorf("expected forwarded headers to be set on the cloned request, got %v", base.gotReq.Header)
+	}
+
+	if len(base.gotReq.Header) == 0 {
+		t.Errorf("expected span context to be injected into the cloned request's headers")
+	}
+}
+
+func TestTracingTransportTagsStatusAndError(t *testing.T) {
+	tracer := mocktracer.New()
+	prev := opentracing.GlobalTracer()
+	opentracing.SetGlobalTracer(tracer)
+	defer opentracing.SetGlobalTracer(prev)
+
+	t.Run("5xx response is tagged as error", func(t *testing.T) {
+		base := &fakeRoundTripper{resp: &http.Response{StatusCode: http.StatusInternalServerError}}
+		transport := NewTransport(base)
+		req := newTracedRequest(t, tracer)
+
+		if _, err := transport.RoundTrip(req); err != nil {
+			t.Fatalf("RoundTrip: %v", err)
+		}
+
+		span := tracer.FinishedSpans()[len(tracer.FinishedSpans())-1]
+		if got := span.Tag("http.status_code"); got != uint16(http.StatusInternalServerError) {
+			t.Errorf("http.status_code tag = %v, want %v", got, http.StatusInternalServerError)
+		}
+
+		if got := span.Tag("error"); got != "true" {
+			t.Errorf("error tag = %v, want %q", got, "true")
+		}
+	})
+
+	t.Run("transport error is recorded", func(t *testing.T) {
+		wantErr := errors.New("boom")
+		base := &fakeRoundTripper{err: wantErr}
+		transport := NewTransport(base)
+		req := newTracedRequest(t, tracer)
+
+		if _, err := transport.RoundTrip(req); err != wantErr {
+			t.Fatalf("RoundTrip() err = %v, want %v", err, wantErr)
+		}
+
+		span := tracer.FinishedSpans()[len(tracer.FinishedSpans())-1]
+		if got := span.Tag("error"); got != "true" {
+			t.Errorf("error tag = %v, want %q", got, "true")
+		}
+	})
+}
+
+func TestTracingTransportSkipsWithoutParentSpan(t *testing.T) {
+	base := &fakeRoundTripper{resp: &http.Response{StatusCode: http.StatusOK}}
+	transport := NewTransport(base)
+
+	req, err := http.NewRequest(http.MethodGet, "http://example.com/path", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+
+	if _, err := transport.RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+
+	if base.gotReq != req {
+		t.Errorf("expected the original request to be passed through untouched when there is no parent span")
+	}
+}